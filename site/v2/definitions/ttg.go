@@ -7,6 +7,7 @@ import (
 	"path"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
@@ -19,6 +20,57 @@ import (
 // 继承 NexusPHPDriver 的所有功能，但重写 GetTorrentDetail 以处理 TTG 特殊的免费时间格式
 type ttgDriver struct {
 	*v2.NexusPHPDriver
+	// hosts resolves TTG's current domain against SiteDefinition.FormerHosts
+	// (e.g. a future totheglory.im migration), so link normalization keeps
+	// working even when a user's config still points at a retired host.
+	hosts *v2.HostFailover
+	// logger receives warnings for best-effort steps (the host probe and
+	// enrichment) that shouldn't fail the request they're attached to.
+	logger *zap.Logger
+
+	// cookie and siteDef are kept so the embedded NexusPHPDriver can be
+	// rebuilt against a different candidate host (see rebuildNexusDriver)
+	// without re-parsing config.
+	cookie  string
+	siteDef *v2.SiteDefinition
+	// probeOnce runs hosts.ProbeActive lazily, on the first
+	// GetTorrentDetail call, so constructing the driver itself never
+	// makes a network call. Requests served directly by the embedded
+	// NexusPHPDriver (torrent list, user info) do not trigger the probe.
+	probeOnce sync.Once
+}
+
+// ensureActiveHost probes hosts (once) to resolve the first request to
+// whichever candidate host is actually serving the site, then rebuilds
+// the embedded NexusPHPDriver to match. A probe failure just leaves the
+// primary host active; the retry loop in fetchDetailDocument still
+// covers a host that was up during the probe but drops mid-session.
+func (d *ttgDriver) ensureActiveHost() {
+	d.probeOnce.Do(func() {
+		if d.hosts == nil {
+			return
+		}
+		if err := d.hosts.ProbeActive(); err != nil {
+			d.logger.Warn("probe TTG candidate hosts", zap.Error(err))
+			return
+		}
+		d.rebuildNexusDriver()
+	})
+}
+
+// rebuildNexusDriver replaces the embedded NexusPHPDriver with one
+// pointed at hosts.ActiveBaseURL(), used whenever the active host
+// changes after construction (initial probe, or a mid-request
+// failover).
+func (d *ttgDriver) rebuildNexusDriver() {
+	nexusDriver := v2.NewNexusPHPDriver(v2.NexusPHPDriverConfig{
+		BaseURL: d.hosts.ActiveBaseURL(),
+		Cookie:  d.cookie,
+	})
+	if d.siteDef != nil {
+		nexusDriver.SetSiteDefinition(d.siteDef)
+	}
+	d.NexusPHPDriver = nexusDriver
 }
 
 // TTGDefinition is the site definition for TTG (To The Glory)
@@ -363,9 +415,18 @@ func createTTGDriver(config v2.SiteConfig, logger *zap.Logger) (v2.Site, error)
 		baseURL = siteDef.URLs[0]
 	}
 
+	// 站点可能已经换过域名（formerHosts），记录候选域名列表；探测延迟到
+	// ttgDriver.ensureActiveHost（首次请求时才调用），构造函数本身保持
+	// 纯粹、不发起网络请求。
+	var formerHosts []string
+	if siteDef != nil {
+		formerHosts = siteDef.FormerHosts
+	}
+	hosts := v2.NewHostFailover(baseURL, formerHosts)
+
 	// 创建标准 NexusPHP 驱动
 	nexusDriver := v2.NewNexusPHPDriver(v2.NexusPHPDriverConfig{
-		BaseURL: baseURL,
+		BaseURL: hosts.ActiveBaseURL(),
 		Cookie:  opts.Cookie,
 	})
 
@@ -373,9 +434,15 @@ func createTTGDriver(config v2.SiteConfig, logger *zap.Logger) (v2.Site, error)
 		nexusDriver.SetSiteDefinition(siteDef)
 	}
 
+	siteLogger := logger.With(zap.String("site", config.ID))
+
 	// 包装为 ttgDriver
 	driver := &ttgDriver{
 		NexusPHPDriver: nexusDriver,
+		hosts:          hosts,
+		logger:         siteLogger,
+		cookie:         opts.Cookie,
+		siteDef:        siteDef,
 	}
 
 	return v2.NewBaseSite(driver, v2.BaseSiteConfig{
@@ -384,34 +451,65 @@ func createTTGDriver(config v2.SiteConfig, logger *zap.Logger) (v2.Site, error)
 		Kind:      v2.SiteNexusPHP,
 		RateLimit: config.RateLimit,
 		RateBurst: config.RateBurst,
-		Logger:    logger.With(zap.String("site", config.ID)),
+		Logger:    siteLogger,
 	}), nil
 }
 
+// fetchDetailDocument executes the detail request for torrentID,
+// retrying against the next candidate host (rebuilding the embedded
+// NexusPHPDriver to match) whenever Execute itself fails, up to one
+// attempt per candidate host — the same retry-on-failure shape
+// UNIT3DDriver.getWithFailover uses.
+func (d *ttgDriver) fetchDetailDocument(ctx context.Context, torrentID string) (*goquery.Selection, error) {
+	attempts := 1
+	if d.hosts != nil {
+		attempts = d.hosts.CandidateCount()
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		req, err := d.PrepareDetail(torrentID)
+		if err != nil {
+			return nil, fmt.Errorf("prepare detail request for torrent %s: %w", torrentID, err)
+		}
+
+		res, err := d.Execute(ctx, req)
+		if err != nil {
+			lastErr = err
+			if d.hosts == nil || !d.hosts.Advance() {
+				break
+			}
+			d.rebuildNexusDriver()
+			continue
+		}
+
+		if res.Document == nil {
+			return nil, v2.ErrParseError
+		}
+		return res.Document.Selection, nil
+	}
+	return nil, fmt.Errorf("execute detail request for torrent %s: %w", torrentID, lastErr)
+}
+
 // GetTorrentDetail 重写以处理 TTG 特殊的免费时间格式
 func (d *ttgDriver) GetTorrentDetail(ctx context.Context, guid, link string) (*v2.TorrentItem, error) {
-	// 仅使用 Link 字段提取种子 ID，不使用 GUID
+	d.ensureActiveHost()
+
+	// 仅使用 Link 字段提取种子 ID，不使用 GUID；先归一化到当前生效域名，
+	// 这样即使站点换过域名，Cookie 按域名生效的问题也不会受影响。
+	if d.hosts != nil {
+		link = d.hosts.NormalizeToActiveHost(link)
+	}
 	torrentID := extractTTGTorrentIDFromLink(link)
 	if torrentID == "" {
 		return nil, fmt.Errorf("无法从 link 提取种子 ID: %s", link)
 	}
 
-	req, err := d.PrepareDetail(torrentID)
+	doc, err := d.fetchDetailDocument(ctx, torrentID)
 	if err != nil {
-		return nil, fmt.Errorf("prepare detail request for torrent %s: %w", torrentID, err)
+		return nil, err
 	}
 
-	res, err := d.Execute(ctx, req)
-	if err != nil {
-		return nil, fmt.Errorf("execute detail request for torrent %s: %w", torrentID, err)
-	}
-
-	if res.Document == nil {
-		return nil, v2.ErrParseError
-	}
-
-	doc := res.Document.Selection
-
 	// 使用标准Parser解析非折扣字段
 	parser := v2.NewNexusPHPParserFromDefinition(d.GetSiteDefinition())
 	title, torrentID := parser.ParseTitleAndID(doc)
@@ -436,6 +534,11 @@ func (d *ttgDriver) GetTorrentDetail(ctx context.Context, guid, link string) (*v
 		SourceSite:      siteID,
 	}
 
+	// 附加信息（IMDb/豆瓣评分、查重）是可选的，不应让整次详情请求失败
+	if err := v2.ApplyEnrichment(ctx, d.GetSiteDefinition(), item, doc); err != nil {
+		d.logger.Warn("enrich torrent detail", zap.String("id", item.ID), zap.Error(err))
+	}
+
 	return item, nil
 }
 
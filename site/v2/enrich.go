@@ -0,0 +1,195 @@
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// EnrichmentConfig is a SiteDefinition.Enrichment block. It's optional;
+// a nil value (the default for every existing site definition) skips
+// enrichment entirely so GetTorrentDetail's behavior is unchanged for
+// sites that don't opt in.
+type EnrichmentConfig struct {
+	// DetailPage enables scraping the detail page itself for IMDb/豆瓣
+	// links, e.g. TTG's details.php.
+	DetailPage bool `yaml:"detailPage" json:"detailPage"`
+	// DupAPI, when set, additionally queries a user-configured
+	// dup-check endpoint with the resolved IMDb/Douban ID to mark
+	// titles already present in e.g. a Plex/Emby library.
+	DupAPI *DupAPIConfig `yaml:"dupApi" json:"dupApi"`
+}
+
+// DupAPIConfig is the endpoint used by DupAPIEnricher.
+type DupAPIConfig struct {
+	URL   string `yaml:"url" json:"url"`
+	Token string `yaml:"token" json:"token"`
+}
+
+// MediaEnricher adds metadata to a TorrentItem that GetTorrentDetail's
+// own parsing doesn't produce. doc is the detail page document, so a
+// DetailPage-only enricher never has to issue a request of its own.
+type MediaEnricher interface {
+	Enrich(ctx context.Context, item *TorrentItem, doc *goquery.Selection) error
+}
+
+var (
+	imdbLinkRe   = regexp.MustCompile(`imdb\.com/title/(tt\d+)`)
+	doubanLinkRe = regexp.MustCompile(`movie\.douban\.com/subject/(\d+)`)
+	imdbRatingRe = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*/\s*10`)
+	// doubanRatingRe matches either the IMDb-style "x/10" or the plain
+	// "x分" 豆瓣 detail pages actually render next to the rating link
+	// (e.g. "豆瓣评分8.3分"); requiring a literal "/10" here left
+	// DoubanRating permanently empty.
+	doubanRatingRe = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*(?:/\s*10|分)`)
+	titleYearRe    = regexp.MustCompile(`^(.*?)[\s([（]+(\d{4})[)）\]]?\s*$`)
+)
+
+// DetailPageEnricher scrapes a detail page for IMDb/豆瓣 links and their
+// ratings, plus the original title/year embedded alongside them, the
+// way TTG's details.php already presents them next to the main title.
+type DetailPageEnricher struct{}
+
+// Enrich implements MediaEnricher.
+func (DetailPageEnricher) Enrich(_ context.Context, item *TorrentItem, doc *goquery.Selection) error {
+	if doc == nil {
+		return nil
+	}
+	doc.Find("a[href*='imdb.com/title/']").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		href, _ := s.Attr("href")
+		m := imdbLinkRe.FindStringSubmatch(href)
+		if len(m) != 2 {
+			return true
+		}
+		item.IMDbID = m[1]
+		if rm := imdbRatingRe.FindStringSubmatch(s.Parent().Text()); len(rm) == 2 {
+			item.IMDbRating, _ = strconv.ParseFloat(rm[1], 64)
+		}
+		return false
+	})
+	doc.Find("a[href*='movie.douban.com/subject/']").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		href, _ := s.Attr("href")
+		m := doubanLinkRe.FindStringSubmatch(href)
+		if len(m) != 2 {
+			return true
+		}
+		item.DoubanID = m[1]
+		if rm := doubanRatingRe.FindStringSubmatch(s.Parent().Text()); len(rm) == 2 {
+			item.DoubanRating, _ = strconv.ParseFloat(rm[1], 64)
+		}
+		return false
+	})
+	if title := strings.TrimSpace(doc.Find("h1").First().Text()); title != "" {
+		if m := titleYearRe.FindStringSubmatch(title); len(m) == 3 {
+			item.OriginalTitle = strings.TrimSpace(m[1])
+			item.Year = m[2]
+		} else {
+			item.OriginalTitle = title
+		}
+	}
+	return nil
+}
+
+// dupAPIResponse is the expected shape of a dup-check endpoint's reply.
+type dupAPIResponse struct {
+	Owned bool `json:"owned"`
+}
+
+// DupAPIEnricher queries a user-configured dup-check endpoint with the
+// torrent's IMDb/Douban ID to mark titles already present in e.g. a
+// Plex/Emby library, mirroring the torfilter dedup workflow without
+// depending on it directly.
+type DupAPIEnricher struct {
+	Config     DupAPIConfig
+	HTTPClient *http.Client
+}
+
+// NewDupAPIEnricher creates a DupAPIEnricher for cfg.
+func NewDupAPIEnricher(cfg DupAPIConfig) *DupAPIEnricher {
+	return &DupAPIEnricher{
+		Config:     cfg,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Enrich implements MediaEnricher. It is a no-op when item has neither
+// an IMDb nor a Douban ID to query with.
+func (e *DupAPIEnricher) Enrich(ctx context.Context, item *TorrentItem, _ *goquery.Selection) error {
+	id := item.IMDbID
+	if id == "" {
+		id = item.DoubanID
+	}
+	if id == "" || e.Config.URL == "" {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, e.Config.URL+"?id="+id, nil)
+	if err != nil {
+		return fmt.Errorf("build dup-api request: %w", err)
+	}
+	if e.Config.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+e.Config.Token)
+	}
+
+	resp, err := e.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("query dup-api: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var out dupAPIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return fmt.Errorf("decode dup-api response: %w", err)
+	}
+	item.AlreadyOwned = out.Owned
+	return nil
+}
+
+// BuildEnrichers returns the MediaEnrichers described by cfg, in the
+// order they should run (cheapest/local first). A nil cfg yields no
+// enrichers.
+func BuildEnrichers(cfg *EnrichmentConfig) []MediaEnricher {
+	if cfg == nil {
+		return nil
+	}
+	var enrichers []MediaEnricher
+	if cfg.DetailPage {
+		enrichers = append(enrichers, DetailPageEnricher{})
+	}
+	if cfg.DupAPI != nil {
+		enrichers = append(enrichers, NewDupAPIEnricher(*cfg.DupAPI))
+	}
+	return enrichers
+}
+
+// ApplyEnrichment runs every enricher configured on def.Enrichment
+// against item/doc, in order. Drivers call this as an optional,
+// best-effort step right after building a TorrentItem in
+// GetTorrentDetail; it's a no-op when def has no Enrichment config.
+//
+// A failing enricher (e.g. a dup-check endpoint timing out) doesn't
+// stop the remaining enrichers from running, and the returned error is
+// informational only — callers should log it and keep the item rather
+// than fail the whole detail fetch over optional metadata.
+func ApplyEnrichment(ctx context.Context, def *SiteDefinition, item *TorrentItem, doc *goquery.Selection) error {
+	if def == nil || def.Enrichment == nil {
+		return nil
+	}
+	var errs []string
+	for _, enricher := range BuildEnrichers(def.Enrichment) {
+		if err := enricher.Enrich(ctx, item, doc); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("enrich torrent %s: %s", item.ID, strings.Join(errs, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package v2
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetailPageEnricher(t *testing.T) {
+	html := `<html><body>
+		<a href="https://www.imdb.com/title/tt1234567/">IMDb</a>
+		<a href="https://movie.douban.com/subject/987654/">豆瓣</a>
+	</body></html>`
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	require.NoError(t, err)
+
+	item := &TorrentItem{ID: "1"}
+	require.NoError(t, DetailPageEnricher{}.Enrich(context.Background(), item, doc.Selection))
+
+	assert.Equal(t, "tt1234567", item.IMDbID)
+	assert.Equal(t, "987654", item.DoubanID)
+}
+
+func TestApplyEnrichment_NilConfig(t *testing.T) {
+	item := &TorrentItem{ID: "1"}
+	require.NoError(t, ApplyEnrichment(context.Background(), &SiteDefinition{}, item, nil))
+	assert.Empty(t, item.IMDbID)
+}
+
+func TestDetailPageEnricher_DoubanRatingWithoutSlash10(t *testing.T) {
+	html := `<html><body>
+		<p>豆瓣评分<a href="https://movie.douban.com/subject/987654/">8.3分</a></p>
+	</body></html>`
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	require.NoError(t, err)
+
+	item := &TorrentItem{ID: "1"}
+	require.NoError(t, DetailPageEnricher{}.Enrich(context.Background(), item, doc.Selection))
+
+	assert.Equal(t, "987654", item.DoubanID)
+	assert.Equal(t, 8.3, item.DoubanRating)
+}
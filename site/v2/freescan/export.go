@@ -0,0 +1,77 @@
+package freescan
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// ExportText writes one download link per line, in the order given
+// (callers typically pass the Scan result as-is, soonest-to-expire
+// first).
+func ExportText(w io.Writer, torrents []FreeTorrent) error {
+	for _, t := range torrents {
+		if _, err := fmt.Fprintln(w, t.Link); err != nil {
+			return fmt.Errorf("write text export: %w", err)
+		}
+	}
+	return nil
+}
+
+// ExportJSON writes torrents as a JSON array.
+func ExportJSON(w io.Writer, torrents []FreeTorrent) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(torrents); err != nil {
+		return fmt.Errorf("write json export: %w", err)
+	}
+	return nil
+}
+
+// rssFeed and rssItem model the minimal RSS 2.0 shape download clients
+// (qBittorrent, Transmission, etc.) expect from an "RSS feed" source.
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title string    `xml:"title"`
+	Items []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title string `xml:"title"`
+	Link  string `xml:"link"`
+	GUID  string `xml:"guid"`
+}
+
+// ExportRSS writes torrents as an RSS 2.0 feed consumable by download
+// clients' RSS-rule features.
+func ExportRSS(w io.Writer, torrents []FreeTorrent) error {
+	feed := rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title: "pt-tools free torrents",
+		},
+	}
+	for _, t := range torrents {
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title: t.Title,
+			Link:  t.Link,
+			GUID:  t.SourceSite + ":" + t.ID,
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("write rss export: %w", err)
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(feed); err != nil {
+		return fmt.Errorf("write rss export: %w", err)
+	}
+	return nil
+}
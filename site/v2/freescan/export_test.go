@@ -0,0 +1,47 @@
+package freescan
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v2 "github.com/sunerpy/pt-tools/site/v2"
+)
+
+func TestIsDiscounted(t *testing.T) {
+	assert.True(t, isDiscounted(v2.DiscountFree))
+	assert.True(t, isDiscounted(v2.Discount2xFree))
+	assert.True(t, isDiscounted(v2.DiscountPercent50))
+	assert.False(t, isDiscounted(v2.DiscountNone))
+}
+
+func TestExportText(t *testing.T) {
+	torrents := []FreeTorrent{
+		{Link: "https://example.com/a.torrent"},
+		{Link: "https://example.com/b.torrent"},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, ExportText(&buf, torrents))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, []string{"https://example.com/a.torrent", "https://example.com/b.torrent"}, lines)
+}
+
+func TestExportJSON(t *testing.T) {
+	torrents := []FreeTorrent{{ID: "1", Title: "Example", Remaining: time.Hour}}
+	var buf bytes.Buffer
+	require.NoError(t, ExportJSON(&buf, torrents))
+	assert.Contains(t, buf.String(), `"Title": "Example"`)
+}
+
+func TestExportRSS(t *testing.T) {
+	torrents := []FreeTorrent{{ID: "1", SourceSite: "ttg", Title: "Example", Link: "https://example.com/1"}}
+	var buf bytes.Buffer
+	require.NoError(t, ExportRSS(&buf, torrents))
+	out := buf.String()
+	assert.Contains(t, out, "<title>Example</title>")
+	assert.Contains(t, out, "<guid>ttg:1</guid>")
+}
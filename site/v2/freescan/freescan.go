@@ -0,0 +1,162 @@
+// Package freescan aggregates free (or discounted) torrents across every
+// registered site, so a user can see what's worth grabbing before the
+// discount window closes without clicking through each tracker by hand.
+package freescan
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	v2 "github.com/sunerpy/pt-tools/site/v2"
+)
+
+// FreeTorrent is a torrent with a free/discounted window, annotated with
+// how much of that window remains.
+type FreeTorrent struct {
+	SourceSite      string
+	ID              string
+	Title           string
+	Link            string
+	SizeBytes       int64
+	DiscountLevel   v2.DiscountLevel
+	DiscountEndTime time.Time
+	Remaining       time.Duration
+}
+
+// FreeScannerConfig controls what FreeScanner.Scan includes.
+type FreeScannerConfig struct {
+	// MinRemaining drops torrents whose discount window ends sooner
+	// than this, e.g. to skip entries about to expire before a
+	// download could realistically start.
+	MinRemaining time.Duration
+	// MaxSizeBytes drops torrents larger than this size when > 0.
+	MaxSizeBytes int64
+	// Concurrency bounds how many sites are scanned at once.
+	Concurrency int
+	// RateLimit and RateBurst throttle the overall scan the same way
+	// BaseSite throttles a single site's requests.
+	RateLimit float64
+	RateBurst int
+}
+
+// FreeScanner iterates every registered Site, pulls its torrent list,
+// and filters down to entries currently running a free/discounted
+// promotion.
+type FreeScanner struct {
+	cfg     FreeScannerConfig
+	limiter *rate.Limiter
+}
+
+// NewFreeScanner creates a FreeScanner with the given config, applying
+// repo-standard defaults (4-way concurrency, no size cap) for zero
+// values.
+func NewFreeScanner(cfg FreeScannerConfig) *FreeScanner {
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 4
+	}
+	var limiter *rate.Limiter
+	if cfg.RateLimit > 0 {
+		burst := cfg.RateBurst
+		if burst <= 0 {
+			burst = 1
+		}
+		limiter = rate.NewLimiter(rate.Limit(cfg.RateLimit), burst)
+	}
+	return &FreeScanner{cfg: cfg, limiter: limiter}
+}
+
+// isDiscounted reports whether level counts as a "free torrent" for
+// scanning purposes.
+func isDiscounted(level v2.DiscountLevel) bool {
+	switch level {
+	case v2.DiscountFree, v2.Discount2xFree, v2.DiscountPercent50:
+		return true
+	default:
+		return false
+	}
+}
+
+// Scan queries every site returned by v2.RegisteredSites(), in parallel
+// up to cfg.Concurrency, and returns the matching free torrents sorted
+// ascending by remaining time (soonest-to-expire first).
+func (f *FreeScanner) Scan(ctx context.Context, now time.Time) ([]FreeTorrent, error) {
+	sites := v2.RegisteredSites()
+
+	var (
+		mu       sync.Mutex
+		results  []FreeTorrent
+		firstErr error
+	)
+
+	sem := make(chan struct{}, f.cfg.Concurrency)
+	var wg sync.WaitGroup
+
+	for _, site := range sites {
+		site := site
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if f.limiter != nil {
+				if err := f.limiter.Wait(ctx); err != nil {
+					return
+				}
+			}
+
+			items, err := site.GetTorrentList(ctx)
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+				return
+			}
+
+			var found []FreeTorrent
+			for _, item := range items {
+				if item == nil || !isDiscounted(item.DiscountLevel) {
+					continue
+				}
+				if f.cfg.MaxSizeBytes > 0 && item.SizeBytes > f.cfg.MaxSizeBytes {
+					continue
+				}
+				remaining := item.DiscountEndTime.Sub(now)
+				if item.DiscountEndTime.IsZero() {
+					remaining = 0
+				}
+				if remaining < f.cfg.MinRemaining {
+					continue
+				}
+				found = append(found, FreeTorrent{
+					SourceSite:      item.SourceSite,
+					ID:              item.ID,
+					Title:           item.Title,
+					Link:            item.Link,
+					SizeBytes:       item.SizeBytes,
+					DiscountLevel:   item.DiscountLevel,
+					DiscountEndTime: item.DiscountEndTime,
+					Remaining:       remaining,
+				})
+			}
+
+			mu.Lock()
+			results = append(results, found...)
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Remaining < results[j].Remaining
+	})
+
+	return results, firstErr
+}
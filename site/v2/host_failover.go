@@ -0,0 +1,125 @@
+package v2
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// HostFailover tracks a primary base URL plus a list of former hosts a
+// site has migrated away from (SiteDefinition.FormerHosts), and probes
+// through them in order until it finds one that's actually serving the
+// site. Trackers occasionally move domains (e.g. fsm.name moving to
+// nextpt.net) while old configs and bookmarks keep pointing at the
+// retired host, which otherwise shows up as login redirects or
+// connection failures instead of a clear error.
+type HostFailover struct {
+	candidates []string
+	active     int
+	client     *http.Client
+}
+
+// NewHostFailover builds a HostFailover for primary plus formerHosts,
+// deduplicated and defaulting to primary as the active host until
+// ProbeActive is called.
+func NewHostFailover(primary string, formerHosts []string) *HostFailover {
+	candidates := make([]string, 0, 1+len(formerHosts))
+	seen := map[string]bool{}
+	for _, host := range append([]string{primary}, formerHosts...) {
+		host = strings.TrimRight(host, "/")
+		if host == "" || seen[host] {
+			continue
+		}
+		seen[host] = true
+		candidates = append(candidates, host)
+	}
+	if len(candidates) == 0 {
+		candidates = []string{""}
+	}
+	return &HostFailover{
+		candidates: candidates,
+		client:     &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// ActiveBaseURL returns the currently active candidate base URL.
+func (h *HostFailover) ActiveBaseURL() string {
+	return h.candidates[h.active]
+}
+
+// CandidateCount returns how many candidate hosts are known.
+func (h *HostFailover) CandidateCount() int {
+	return len(h.candidates)
+}
+
+// Advance moves to the next candidate host, reporting false (and
+// leaving the active host unchanged) once the list is exhausted.
+func (h *HostFailover) Advance() bool {
+	if h.active+1 >= len(h.candidates) {
+		return false
+	}
+	h.active++
+	return true
+}
+
+// ProbeActive issues a lightweight GET against the active host and, on
+// a connection failure or a redirect to a host outside the candidate
+// list (a login-page bounce), advances to the next candidate and
+// retries, stopping once a candidate responds or the list is
+// exhausted. When the list is exhausted it returns the last error and
+// leaves the active host as it was before the probe.
+func (h *HostFailover) ProbeActive() error {
+	start := h.active
+	var lastErr error
+	for i := h.active; i < len(h.candidates); i++ {
+		h.active = i
+		resp, err := h.client.Get(h.ActiveBaseURL() + "/")
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		resp.Body.Close()
+		if h.isForeignRedirect(resp) {
+			lastErr = fmt.Errorf("%s redirected to a foreign host", h.ActiveBaseURL())
+			continue
+		}
+		return nil
+	}
+	h.active = start
+	return lastErr
+}
+
+// isForeignRedirect reports whether resp ended up somewhere outside
+// h.candidates, the signature of a tracker bouncing a retired host to
+// its login page on a different domain.
+func (h *HostFailover) isForeignRedirect(resp *http.Response) bool {
+	if resp.Request == nil || resp.Request.URL == nil {
+		return false
+	}
+	finalHost := resp.Request.URL.Scheme + "://" + resp.Request.URL.Host
+	for _, candidate := range h.candidates {
+		if strings.HasPrefix(candidate, finalHost) {
+			return false
+		}
+	}
+	return true
+}
+
+// NormalizeToActiveHost rewrites an absolute link returned by the site
+// (which may still point at a former host) so it points at the
+// currently active host instead, keeping per-host cookies valid.
+func (h *HostFailover) NormalizeToActiveHost(link string) string {
+	parsed, err := url.Parse(link)
+	if err != nil || !parsed.IsAbs() {
+		return link
+	}
+	active, err := url.Parse(h.ActiveBaseURL())
+	if err != nil {
+		return link
+	}
+	parsed.Scheme = active.Scheme
+	parsed.Host = active.Host
+	return parsed.String()
+}
@@ -0,0 +1,35 @@
+package v2
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHostFailover_ProbeActiveFallsBackToFormerHost(t *testing.T) {
+	good := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer good.Close()
+
+	hosts := NewHostFailover("http://127.0.0.1:1", []string{good.URL})
+	require.NoError(t, hosts.ProbeActive())
+	assert.Equal(t, good.URL, hosts.ActiveBaseURL())
+}
+
+func TestHostFailover_NormalizeToActiveHost(t *testing.T) {
+	hosts := NewHostFailover("https://new.example", []string{"https://old.example"})
+	hosts.Advance()
+	assert.Equal(t, "https://old.example", hosts.ActiveBaseURL())
+
+	got := hosts.NormalizeToActiveHost("https://new.example/details.php?id=123")
+	assert.Equal(t, "https://old.example/details.php?id=123", got)
+}
+
+func TestHostFailover_DeduplicatesCandidates(t *testing.T) {
+	hosts := NewHostFailover("https://a.example/", []string{"https://a.example", "https://b.example/"})
+	assert.Equal(t, 2, hosts.CandidateCount())
+}
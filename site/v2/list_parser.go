@@ -0,0 +1,312 @@
+package v2
+
+import (
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// sizeUnitMultipliers maps a size suffix to its byte count, matching
+// the units NexusPHP/UNIT3D themes render ("GiB"/"GB" are both treated
+// as binary multiples, consistent with how trackers actually report
+// size).
+var sizeUnitMultipliers = map[string]float64{
+	"B":   1,
+	"KB":  1 << 10,
+	"KIB": 1 << 10,
+	"MB":  1 << 20,
+	"MIB": 1 << 20,
+	"GB":  1 << 30,
+	"GIB": 1 << 30,
+	"TB":  1 << 40,
+	"TIB": 1 << 40,
+	"PB":  1 << 50,
+	"PIB": 1 << 50,
+}
+
+var sizeWithUnitRe = regexp.MustCompile(`([\d.,]+)\s*([KMGTP]i?B)`)
+
+// parseSizeToBytes parses a size string such as "4.5 GiB" or "500 GB"
+// into a byte count, the same unit handling TTG's own parseSize filter
+// relies on.
+func parseSizeToBytes(text string) int64 {
+	matches := sizeWithUnitRe.FindStringSubmatch(text)
+	if len(matches) != 3 {
+		return 0
+	}
+	value, err := strconv.ParseFloat(strings.ReplaceAll(matches[1], ",", ""), 64)
+	if err != nil {
+		return 0
+	}
+	mult, ok := sizeUnitMultipliers[strings.ToUpper(matches[2])]
+	if !ok {
+		return 0
+	}
+	return int64(value * mult)
+}
+
+// NexusPHPParser extracts torrent-list and detail-page fields using the
+// selectors on a SiteDefinition. It is shared by NexusPHPDriver and
+// UNIT3DDriver's HTML fallback so each site definition only has to
+// describe its selectors, not reimplement the extraction logic.
+type NexusPHPParser struct {
+	def *SiteDefinition
+}
+
+// NewNexusPHPParserFromDefinition builds a parser bound to def's
+// selectors. def may be nil, in which case parsing returns zero
+// values rather than panicking.
+func NewNexusPHPParserFromDefinition(def *SiteDefinition) *NexusPHPParser {
+	return &NexusPHPParser{def: def}
+}
+
+func (p *NexusPHPParser) selectors() *SiteSelectors {
+	if p.def == nil {
+		return nil
+	}
+	return p.def.Selectors
+}
+
+// rowRoot returns the selection a row's columnar fields should be read
+// from: the row itself, or the descendant matched by RowDataSelector
+// when the site's theme nests the real data further down (e.g. a
+// double-row layout).
+func (p *NexusPHPParser) rowRoot(row *goquery.Selection) *goquery.Selection {
+	sel := p.selectors()
+	if rowSel := sel.rowDataSelector(); rowSel != "" {
+		if found := row.Find(rowSel); found.Length() > 0 {
+			return found.First()
+		}
+	}
+	return row
+}
+
+// cell returns the nth (1-indexed, matching :nth-child semantics used
+// throughout the existing site definitions) column found under root,
+// resolved via DataCellSelector. root is expected to already be a row
+// or its RowDataSelector descendant (see rowRoot).
+//
+// A selector starting with ">" (the classic ">td" default, meaning
+// "direct children only") has to go through ChildrenFiltered instead of
+// Find: cascadia rejects a selector that begins with a bare combinator,
+// so Find(">td") silently matches nothing.
+func (p *NexusPHPParser) cell(root *goquery.Selection, n int) *goquery.Selection {
+	sel := p.selectors().cellSelector()
+	var cells *goquery.Selection
+	if strings.HasPrefix(sel, ">") {
+		cells = root.ChildrenFiltered(strings.TrimSpace(strings.TrimPrefix(sel, ">")))
+	} else {
+		cells = root.Find(sel)
+	}
+	return cells.Eq(n - 1)
+}
+
+// ParseTorrentList extracts every row matched by Selectors.TableRows
+// into a TorrentItem.
+func (p *NexusPHPParser) ParseTorrentList(doc *goquery.Selection, siteID string) ([]*TorrentItem, error) {
+	sel := p.selectors()
+	if sel == nil || sel.TableRows == "" {
+		return nil, nil
+	}
+
+	var items []*TorrentItem
+	doc.Find(sel.TableRows).Each(func(_ int, row *goquery.Selection) {
+		root := p.rowRoot(row)
+		title := strings.TrimSpace(root.Find(sel.Title).First().Text())
+		if title == "" {
+			return
+		}
+		link, _ := root.Find(sel.TitleLink).First().Attr("href")
+
+		item := &TorrentItem{
+			Title:      title,
+			Link:       p.resolveLink(link),
+			SourceSite: siteID,
+		}
+		item.ID = extractTrailingID(link)
+
+		item.DiscountLevel = p.parseDiscount(root, sel)
+		item.SizeBytes = p.parseSizeBytes(root, sel.Size, 1)
+		item.Seeders = int(p.parseNumericField(root, sel.Seeders, 2))
+		item.Leechers = int(p.parseNumericField(root, sel.Leechers, 3))
+		item.Snatched = int(p.parseNumericField(root, sel.Snatched, 4))
+
+		items = append(items, item)
+	})
+	return items, nil
+}
+
+// parseDiscount matches Selectors.DiscountIcon against the mapping and
+// returns the corresponding DiscountLevel, or DiscountNone. NexusPHP
+// themes (TTG included) mark discounts via the icon's src filename
+// (e.g. "ico_free.gif"), while UNIT3D-style themes use a class (e.g.
+// "badge-freeleech"), so both attributes are checked.
+func (p *NexusPHPParser) parseDiscount(root *goquery.Selection, sel *SiteSelectors) DiscountLevel {
+	if sel == nil || sel.DiscountIcon == "" {
+		return DiscountNone
+	}
+	level := DiscountNone
+	root.Find(sel.DiscountIcon).EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		class, _ := s.Attr("class")
+		src, _ := s.Attr("src")
+		base := path.Base(src)
+		base = strings.TrimSuffix(base, path.Ext(base))
+		for key, mapped := range sel.DiscountMapping {
+			if strings.Contains(class, key) || strings.Contains(base, key) {
+				level = mapped
+				return false
+			}
+		}
+		return true
+	})
+	return level
+}
+
+// resolveLink turns a possibly-relative href into an absolute link
+// against the site's first configured URL, so FreeTorrent.Link (and
+// anything else downstream) gets a usable link regardless of whether
+// the theme emits relative or absolute hrefs.
+func (p *NexusPHPParser) resolveLink(href string) string {
+	if href == "" {
+		return ""
+	}
+	if strings.HasPrefix(href, "http://") || strings.HasPrefix(href, "https://") {
+		return href
+	}
+	if p.def == nil || len(p.def.URLs) == 0 {
+		return href
+	}
+	base := strings.TrimRight(p.def.URLs[0], "/")
+	return base + "/" + strings.TrimLeft(href, "/")
+}
+
+// parseNumericField reads a row's bare numeric field (seeders,
+// leechers, snatched count) via fieldSelector when set, falling back to
+// the positional cell found via DataCellSelector/ordinalFallback for
+// themes (hhanclub-style, UNIT3D cards) that don't expose a stable CSS
+// selector per column.
+func (p *NexusPHPParser) parseNumericField(root *goquery.Selection, fieldSelector string, ordinalFallback int) float64 {
+	text := p.fieldText(root, fieldSelector, ordinalFallback)
+	text = strings.TrimSpace(strings.ReplaceAll(text, ",", ""))
+	if text == "" {
+		return 0
+	}
+	value, _ := strconv.ParseFloat(text, 64)
+	return value
+}
+
+// parseSizeBytes reads a row's size field the same way
+// parseNumericField does, but through the unit-aware size parser
+// instead of a bare float — size cells carry a unit suffix ("4.5 GiB",
+// "500 GB"), unlike seeders/leechers/snatched counts.
+func (p *NexusPHPParser) parseSizeBytes(root *goquery.Selection, fieldSelector string, ordinalFallback int) int64 {
+	text := p.fieldText(root, fieldSelector, ordinalFallback)
+	return parseSizeToBytes(text)
+}
+
+func (p *NexusPHPParser) fieldText(root *goquery.Selection, fieldSelector string, ordinalFallback int) string {
+	if fieldSelector != "" {
+		return root.Find(fieldSelector).First().Text()
+	}
+	return p.cell(root, ordinalFallback).Text()
+}
+
+// ParseTitleAndID extracts a detail page's title and torrent ID using
+// DetailParser.TitleSelector/IDSelector.
+func (p *NexusPHPParser) ParseTitleAndID(doc *goquery.Selection) (string, string) {
+	if p.def == nil || p.def.DetailParser == nil {
+		return "", ""
+	}
+	dp := p.def.DetailParser
+	title := strings.TrimSpace(doc.Find(dp.TitleSelector).First().Text())
+	id := ""
+	if dp.IDSelector != "" {
+		href, _ := doc.Find(dp.IDSelector).First().Attr("href")
+		id = extractTrailingID(href)
+	}
+	return title, id
+}
+
+// ParseSizeMB extracts a detail page's size, in megabytes, using
+// DetailParser.SizeSelector/SizeRegex.
+func (p *NexusPHPParser) ParseSizeMB(doc *goquery.Selection) float64 {
+	if p.def == nil || p.def.DetailParser == nil {
+		return 0
+	}
+	dp := p.def.DetailParser
+	text := doc.Find(dp.SizeSelector).First().Text()
+	size, _ := parseSizeWithRegex(text, dp.SizeRegex)
+	return size
+}
+
+// ParseHR reports whether a detail page matches any of
+// DetailParser.HRKeywords.
+func (p *NexusPHPParser) ParseHR(doc *goquery.Selection) bool {
+	if p.def == nil || p.def.DetailParser == nil {
+		return false
+	}
+	text := doc.Text()
+	for _, kw := range p.def.DetailParser.HRKeywords {
+		if kw != "" && strings.Contains(text, kw) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseUserInfo is a minimal fallback used when a driver has no API
+// token and must scrape UserInfoConfig.Selectors directly off the
+// current page.
+func (p *NexusPHPParser) ParseUserInfo(doc *goquery.Selection) *UserInfo {
+	info := &UserInfo{}
+	if p.def == nil || p.def.UserInfo == nil {
+		return info
+	}
+	if sel, ok := p.def.UserInfo.Selectors["id"]; ok {
+		info.ID = strings.TrimSpace(doc.Find(firstOf(sel.Selector)).First().Text())
+	}
+	if sel, ok := p.def.UserInfo.Selectors["name"]; ok {
+		info.Name = strings.TrimSpace(doc.Find(firstOf(sel.Selector)).First().Text())
+	}
+	return info
+}
+
+func firstOf(selectors []string) string {
+	if len(selectors) == 0 {
+		return ""
+	}
+	return selectors[0]
+}
+
+func extractTrailingID(link string) string {
+	if link == "" {
+		return ""
+	}
+	if idx := strings.Index(link, "id="); idx != -1 {
+		rest := link[idx+3:]
+		if amp := strings.IndexByte(rest, '&'); amp != -1 {
+			rest = rest[:amp]
+		}
+		return rest
+	}
+	trimmed := strings.TrimSuffix(link, "/")
+	if idx := strings.LastIndex(trimmed, "/"); idx != -1 {
+		return trimmed[idx+1:]
+	}
+	return trimmed
+}
+
+func parseSizeWithRegex(text, pattern string) (float64, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return 0, err
+	}
+	matches := re.FindStringSubmatch(text)
+	if len(matches) < 2 {
+		return 0, nil
+	}
+	return strconv.ParseFloat(matches[1], 64)
+}
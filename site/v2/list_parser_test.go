@@ -0,0 +1,21 @@
+package v2
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNexusPHPParser_CellDefaultSelector(t *testing.T) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(
+		`<table><tr><td>first</td><td>second</td></tr></table>`,
+	))
+	require.NoError(t, err)
+
+	parser := NewNexusPHPParserFromDefinition(nil)
+	row := doc.Find("tr").First()
+	assert.Equal(t, "second", parser.cell(row, 2).Text())
+}
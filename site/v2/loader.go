@@ -0,0 +1,118 @@
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// knownFilterNames mirrors the Filter.Name values understood by the
+// selector pipeline (regex, parseSize, parseNumber, parseTime,
+// querystring, parentText, index). It lets LoadSiteDefinitionFromFile
+// reject a typo'd filter name at load time instead of failing silently
+// the first time the selector runs.
+var knownFilterNames = map[string]bool{
+	"regex":       true,
+	"parseSize":   true,
+	"parseNumber": true,
+	"parseTime":   true,
+	"querystring": true,
+	"parentText":  true,
+	"index":       true,
+}
+
+// LoadSiteDefinitionFromFile reads a single JSON or YAML file (format
+// inferred from the extension) into a SiteDefinition and registers it,
+// exactly as if it had been written as a Go literal in an init() func
+// like TTGDefinition. This lets users add trackers (in particular the
+// long tail of Chinese NexusPHP sites) by dropping in a config file
+// instead of writing Go.
+//
+// SiteDefinition and every struct nested under it (SiteSelectors,
+// EnrichmentConfig, ...) must carry explicit yaml tags for each
+// multi-word field. encoding/json matches keys case-insensitively, but
+// yaml.v3 only matches the lowercased field name unless a yaml tag
+// says otherwise, so a camelCase config key like "tableRows" or
+// "userInfo" binds under JSON but silently falls through to the zero
+// value under YAML without one.
+func LoadSiteDefinitionFromFile(path string) (*SiteDefinition, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read site definition %s: %w", path, err)
+	}
+
+	var def SiteDefinition
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		if err := json.Unmarshal(raw, &def); err != nil {
+			return nil, fmt.Errorf("parse site definition %s: %w", path, err)
+		}
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(raw, &def); err != nil {
+			return nil, fmt.Errorf("parse site definition %s: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported site definition format %q for %s", ext, path)
+	}
+
+	if err := validateSiteDefinition(&def); err != nil {
+		return nil, fmt.Errorf("validate site definition %s: %w", path, err)
+	}
+
+	RegisterSiteDefinition(&def)
+	return &def, nil
+}
+
+// LoadSiteDefinitionsFromDir loads every *.json/*.yaml/*.yml file
+// directly under dir via LoadSiteDefinitionFromFile, returning the
+// definitions it registered. Sub-directories are not traversed.
+func LoadSiteDefinitionsFromDir(dir string) ([]*SiteDefinition, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read site definition dir %s: %w", dir, err)
+	}
+
+	var defs []*SiteDefinition
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".json", ".yaml", ".yml":
+		default:
+			continue
+		}
+		def, err := LoadSiteDefinitionFromFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return defs, fmt.Errorf("load %s: %w", entry.Name(), err)
+		}
+		defs = append(defs, def)
+	}
+	return defs, nil
+}
+
+// validateSiteDefinition does a light sanity pass over a definition
+// loaded from a config file, since the compiler can no longer catch
+// typos the way it would for a Go literal.
+func validateSiteDefinition(def *SiteDefinition) error {
+	if def.ID == "" {
+		return fmt.Errorf("missing id")
+	}
+	if len(def.URLs) == 0 {
+		return fmt.Errorf("site %s: missing urls", def.ID)
+	}
+	if def.UserInfo != nil {
+		for field, sel := range def.UserInfo.Selectors {
+			for _, filter := range sel.Filters {
+				if !knownFilterNames[filter.Name] {
+					return fmt.Errorf("site %s: field %q uses unknown filter %q", def.ID, field, filter.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package v2
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSiteDefinitionFromFile_JSON(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "pignetwork.json")
+	content := `{
+		"id": "pignetwork",
+		"name": "PigNetwork",
+		"schema": "nexusphp",
+		"urls": ["https://pignetwork.me/"]
+	}`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	def, err := LoadSiteDefinitionFromFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "pignetwork", def.ID)
+	assert.Equal(t, SchemaNexusPHP, def.Schema)
+
+	got := GetDefinitionRegistry().GetOrDefault("pignetwork")
+	require.NotNil(t, got)
+	assert.Equal(t, "PigNetwork", got.Name)
+}
+
+func TestLoadSiteDefinitionFromFile_RejectsUnknownFilter(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "bad.yaml")
+	content := `
+id: bad
+name: Bad
+urls: ["https://bad.example/"]
+userInfo:
+  selectors:
+    name:
+      selector: ["a.user"]
+      filters:
+        - name: not_a_real_filter
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	_, err := LoadSiteDefinitionFromFile(path)
+	assert.Error(t, err)
+}
+
+func TestLoadSiteDefinitionsFromDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "crabpt.json"), []byte(`{
+		"id": "crabpt",
+		"name": "CrabPT",
+		"schema": "nexusphp",
+		"urls": ["https://crabpt.vip/"]
+	}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0o644))
+
+	defs, err := LoadSiteDefinitionsFromDir(dir)
+	require.NoError(t, err)
+	require.Len(t, defs, 1)
+	assert.Equal(t, "crabpt", defs[0].ID)
+}
@@ -0,0 +1,11 @@
+package v2
+
+// SchemaUNIT3D identifies sites built on the UNIT3D tracker framework
+// (e.g. the Blutopia family, asiancinema.me). UNIT3D ships a JSON API
+// under /api/torrents and /api/users/{id} in addition to the classic
+// server-rendered pages, unlike NexusPHP which is HTML-only.
+const SchemaUNIT3D Schema = "unit3d"
+
+// SiteUNIT3D is the BaseSite "kind" used for sites created by
+// UNIT3DDriver, mirroring how SiteNexusPHP tags NexusPHP-backed sites.
+const SiteUNIT3D SiteKind = "unit3d"
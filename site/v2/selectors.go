@@ -0,0 +1,70 @@
+package v2
+
+// SiteSelectors describes how to locate a torrent list and pull
+// per-row fields out of it. Most NexusPHP themes lay each row out as
+// `<tr><td>...</td><td>...</td></tr>` and the zero-value selectors
+// below (">td") are enough; sites with a non-standard layout (card
+// lists, nested tables, div/span-based columns) override
+// DataCellSelector and/or RowDataSelector instead of forking the whole
+// parser.
+// Field tags matter here: yaml.v3 matches a struct field against the
+// lowercased field name unless a yaml tag says otherwise, so a camelCase
+// config key like "tableRows" or "discountMapping" binds only because of
+// the explicit tags below — without them it would silently fall through
+// to the zero value.
+type SiteSelectors struct {
+	TableRows string `yaml:"tableRows" json:"tableRows"`
+	Title     string `yaml:"title" json:"title"`
+	TitleLink string `yaml:"titleLink" json:"titleLink"`
+	Subtitle  string `yaml:"subtitle" json:"subtitle"`
+	Size      string `yaml:"size" json:"size"`
+	Seeders   string `yaml:"seeders" json:"seeders"`
+	Leechers  string `yaml:"leechers" json:"leechers"`
+	Snatched  string `yaml:"snatched" json:"snatched"`
+
+	// DataCellSelector selects the per-column cells within a row
+	// matched by TableRows/RowDataSelector. It defaults to ">td" (the
+	// classic NexusPHP direct-child-<td> layout); hhanclub-style
+	// themes and UNIT3D card lists set it to something like
+	// "> div.cell" or "span" instead.
+	DataCellSelector string `yaml:"dataCellSelector" json:"dataCellSelector"`
+
+	// RowDataSelector, when set, is resolved against each TableRows
+	// match to find the descendant block that actually holds a row's
+	// torrent info (title, size, seeders, ...), for themes where that
+	// block isn't the row element itself — e.g. a double-row layout
+	// where TableRows matches an outer <tr> but the cells live in a
+	// nested <table>.
+	RowDataSelector string `yaml:"rowDataSelector" json:"rowDataSelector"`
+
+	DiscountIcon    string                   `yaml:"discountIcon" json:"discountIcon"`
+	DiscountMapping map[string]DiscountLevel `yaml:"discountMapping" json:"discountMapping"`
+	DiscountEndTime string                   `yaml:"discountEndTime" json:"discountEndTime"`
+
+	Category   string `yaml:"category" json:"category"`
+	UploadTime string `yaml:"uploadTime" json:"uploadTime"`
+}
+
+// defaultDataCellSelector is the fallback used when a SiteDefinition
+// doesn't set DataCellSelector, preserving the original ">td" behavior
+// for every site defined before this field existed.
+const defaultDataCellSelector = ">td"
+
+// cellSelector returns the selector to use for a row's columnar
+// fields, falling back to defaultDataCellSelector when unset.
+func (s *SiteSelectors) cellSelector() string {
+	if s == nil || s.DataCellSelector == "" {
+		return defaultDataCellSelector
+	}
+	return s.DataCellSelector
+}
+
+// rowDataSelector returns the selector used to find the descendant
+// block holding a row's torrent info, or "" when the row element
+// itself should be used directly.
+func (s *SiteSelectors) rowDataSelector() string {
+	if s == nil {
+		return ""
+	}
+	return s.RowDataSelector
+}
@@ -0,0 +1,36 @@
+package v2
+
+import "time"
+
+// TorrentItem is the normalized representation of a torrent returned by
+// GetTorrentList/GetTorrentDetail, regardless of which site schema
+// produced it.
+type TorrentItem struct {
+	ID        string
+	Title     string
+	Link      string
+	SizeBytes int64
+	Seeders   int
+	Leechers  int
+	Snatched  int
+
+	DiscountLevel   DiscountLevel
+	DiscountEndTime time.Time
+	HasHR           bool
+
+	SourceSite string
+
+	// The fields below are populated by a MediaEnricher, run as an
+	// optional step after GetTorrentDetail when the site definition
+	// has an Enrichment config. They are zero-valued otherwise.
+	OriginalTitle string
+	Year          string
+	IMDbID        string
+	IMDbRating    float64
+	DoubanID      string
+	DoubanRating  float64
+	// AlreadyOwned is set by a dup-check MediaEnricher (e.g. against a
+	// Plex/Emby library) when the title is already in the user's
+	// collection.
+	AlreadyOwned bool
+}
@@ -0,0 +1,349 @@
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"go.uber.org/zap"
+)
+
+// UNIT3DOptions is the CreateDriver option payload for UNIT3D-backed
+// sites, parsed from SiteConfig.Options.
+//
+// Cookie alone is enough to drive the HTML fallback; setting Token
+// switches the driver over to the JSON API, which is both faster and
+// less likely to break on theme changes.
+type UNIT3DOptions struct {
+	Cookie string `json:"cookie"`
+	Token  string `json:"token"`
+}
+
+// UNIT3DDriverConfig configures a UNIT3DDriver instance.
+type UNIT3DDriverConfig struct {
+	BaseURL string
+	// FormerHosts lists base URLs the site used to live at, tried in
+	// order when BaseURL stops responding or bounces to a different
+	// host's login page. See SiteDefinition.FormerHosts.
+	FormerHosts []string
+	Cookie      string
+	Token       string
+	// Logger receives warnings for best-effort steps (currently just
+	// enrichment) that shouldn't fail the request they're attached to.
+	// Defaults to a no-op logger when nil.
+	Logger *zap.Logger
+}
+
+// UNIT3DDriver implements the Site driver contract for trackers running
+// the UNIT3D codebase. It prefers the JSON API (GET /api/...) when a
+// per-user token is configured, and falls back to scraping the classic
+// torrents/filter listing and detail pages when only a cookie is
+// available, so existing cookie-only config schemas keep working.
+type UNIT3DDriver struct {
+	hosts      *HostFailover
+	cookie     string
+	token      string
+	httpClient *http.Client
+	siteDef    *SiteDefinition
+	logger     *zap.Logger
+}
+
+// NewUNIT3DDriver creates a UNIT3DDriver for the given config.
+func NewUNIT3DDriver(cfg UNIT3DDriverConfig) *UNIT3DDriver {
+	logger := cfg.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &UNIT3DDriver{
+		hosts:      NewHostFailover(strings.TrimRight(cfg.BaseURL, "/"), cfg.FormerHosts),
+		cookie:     cfg.Cookie,
+		token:      cfg.Token,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		logger:     logger,
+	}
+}
+
+// SetSiteDefinition attaches the SiteDefinition the driver was created
+// for, used to resolve selectors for the HTML fallback path.
+func (d *UNIT3DDriver) SetSiteDefinition(def *SiteDefinition) {
+	d.siteDef = def
+}
+
+// GetSiteDefinition returns the SiteDefinition attached via
+// SetSiteDefinition, if any.
+func (d *UNIT3DDriver) GetSiteDefinition() *SiteDefinition {
+	return d.siteDef
+}
+
+// usesAPI reports whether the driver has a token and should prefer the
+// JSON API over HTML scraping.
+func (d *UNIT3DDriver) usesAPI() bool {
+	return d.token != ""
+}
+
+func (d *UNIT3DDriver) newRequest(ctx context.Context, method, reqURL string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build request for %s: %w", reqURL, err)
+	}
+	if d.usesAPI() {
+		req.Header.Set("Authorization", "Bearer "+d.token)
+		req.Header.Set("Accept", "application/json")
+	} else if d.cookie != "" {
+		req.Header.Set("Cookie", d.cookie)
+	}
+	return req, nil
+}
+
+// getWithFailover issues a GET against path on the active host and,
+// on a connection failure or a login redirect to a host outside the
+// candidate hosts (BaseURL plus FormerHosts), advances to the next candidate host and retries
+// once per remaining candidate. http.Client follows redirects itself,
+// so a domain migration or a login bounce shows up as a 200 from a
+// foreign host rather than an error, which is why the response itself
+// (not just err) has to be inspected the same way ProbeActive does.
+func (d *UNIT3DDriver) getWithFailover(ctx context.Context, path string) (*http.Response, error) {
+	var lastErr error
+	for attempt := 0; attempt < d.hosts.CandidateCount(); attempt++ {
+		req, err := d.newRequest(ctx, http.MethodGet, d.hosts.ActiveBaseURL()+path)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := d.httpClient.Do(req)
+		if err != nil {
+			lastErr = err
+			if !d.hosts.Advance() {
+				break
+			}
+			continue
+		}
+		if d.hosts.isForeignRedirect(resp) {
+			resp.Body.Close()
+			lastErr = fmt.Errorf("redirected to a foreign host")
+			if !d.hosts.Advance() {
+				break
+			}
+			continue
+		}
+		return resp, nil
+	}
+	return nil, fmt.Errorf("all candidate hosts failed, last error: %w", lastErr)
+}
+
+func (d *UNIT3DDriver) fetchDocument(ctx context.Context, path string) (*goquery.Document, error) {
+	resp, err := d.getWithFailover(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("execute request for %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("parse document from %s: %w", path, err)
+	}
+	return doc, nil
+}
+
+func (d *UNIT3DDriver) fetchJSON(ctx context.Context, path string, out any) error {
+	resp, err := d.getWithFailover(ctx, path)
+	if err != nil {
+		return fmt.Errorf("execute request for %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, path)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response from %s: %w", path, err)
+	}
+	return nil
+}
+
+// unit3dUserResponse is the relevant subset of GET /api/users/{id}.
+type unit3dUserResponse struct {
+	Data struct {
+		Username   string  `json:"username"`
+		ID         int64   `json:"id"`
+		Uploaded   int64   `json:"uploaded"`
+		Downloaded int64   `json:"downloaded"`
+		Ratio      float64 `json:"ratio"`
+	} `json:"data"`
+}
+
+// GetUserInfo fetches the authenticated user's stats, preferring
+// GET /api/users/{id} when a token is configured.
+func (d *UNIT3DDriver) GetUserInfo(ctx context.Context, userID string) (*UserInfo, error) {
+	if d.usesAPI() {
+		var resp unit3dUserResponse
+		apiPath := fmt.Sprintf("/api/users/%s?api_token=%s", userID, url.QueryEscape(d.token))
+		if err := d.fetchJSON(ctx, apiPath, &resp); err != nil {
+			return nil, fmt.Errorf("fetch user info via API: %w", err)
+		}
+		return &UserInfo{
+			ID:         strconv.FormatInt(resp.Data.ID, 10),
+			Name:       resp.Data.Username,
+			Uploaded:   resp.Data.Uploaded,
+			Downloaded: resp.Data.Downloaded,
+			Ratio:      resp.Data.Ratio,
+		}, nil
+	}
+
+	doc, err := d.fetchDocument(ctx, "/")
+	if err != nil {
+		return nil, fmt.Errorf("fetch user info via HTML: %w", err)
+	}
+	parser := NewNexusPHPParserFromDefinition(d.siteDef)
+	return parser.ParseUserInfo(doc.Selection), nil
+}
+
+// unit3dTorrentResponse is the relevant subset of GET /api/torrents.
+type unit3dTorrentResponse struct {
+	Data []struct {
+		ID          int64  `json:"id"`
+		Name        string `json:"name"`
+		Size        int64  `json:"size"`
+		Free        bool   `json:"free"`
+		DoubleUp    bool   `json:"doubleup"`
+		FreeleechAt string `json:"freeleech_until"`
+	} `json:"data"`
+}
+
+// GetTorrentList returns the current torrent listing, preferring
+// GET /api/torrents when a token is configured and falling back to
+// scraping torrents/filter otherwise.
+func (d *UNIT3DDriver) GetTorrentList(ctx context.Context) ([]*TorrentItem, error) {
+	if d.usesAPI() {
+		var resp unit3dTorrentResponse
+		apiPath := fmt.Sprintf("/api/torrents?api_token=%s", url.QueryEscape(d.token))
+		if err := d.fetchJSON(ctx, apiPath, &resp); err != nil {
+			return nil, fmt.Errorf("fetch torrent list via API: %w", err)
+		}
+		items := make([]*TorrentItem, 0, len(resp.Data))
+		for _, t := range resp.Data {
+			discount := DiscountNone
+			switch {
+			case t.DoubleUp && t.Free:
+				discount = Discount2xFree
+			case t.Free:
+				discount = DiscountFree
+			}
+			var endTime time.Time
+			if t.FreeleechAt != "" {
+				if parsed, err := ParseTimeInCST("2006-01-02 15:04:05", t.FreeleechAt); err == nil {
+					endTime = parsed
+				}
+			}
+			items = append(items, &TorrentItem{
+				ID:              strconv.FormatInt(t.ID, 10),
+				Title:           t.Name,
+				Link:            fmt.Sprintf("%s/torrents/%d", d.hosts.ActiveBaseURL(), t.ID),
+				SizeBytes:       t.Size,
+				DiscountLevel:   discount,
+				DiscountEndTime: endTime,
+				SourceSite:      d.siteID(),
+			})
+		}
+		return items, nil
+	}
+
+	doc, err := d.fetchDocument(ctx, "/torrents/filter")
+	if err != nil {
+		return nil, fmt.Errorf("fetch torrent list via HTML: %w", err)
+	}
+	parser := NewNexusPHPParserFromDefinition(d.siteDef)
+	return parser.ParseTorrentList(doc.Selection, d.siteID())
+}
+
+// GetTorrentDetail fetches a single torrent's detail, preferring
+// GET /api/torrents/{id} when a token is configured.
+func (d *UNIT3DDriver) GetTorrentDetail(ctx context.Context, guid, link string) (*TorrentItem, error) {
+	id := extractUNIT3DTorrentID(d.hosts.NormalizeToActiveHost(link))
+	if id == "" {
+		return nil, fmt.Errorf("无法从 link 提取种子 ID: %s", link)
+	}
+
+	if d.usesAPI() {
+		var resp struct {
+			Data struct {
+				ID   int64  `json:"id"`
+				Name string `json:"name"`
+				Size int64  `json:"size"`
+				Free bool   `json:"free"`
+			} `json:"data"`
+		}
+		apiPath := fmt.Sprintf("/api/torrents/%s?api_token=%s", id, url.QueryEscape(d.token))
+		if err := d.fetchJSON(ctx, apiPath, &resp); err != nil {
+			return nil, fmt.Errorf("fetch torrent detail via API for %s: %w", id, err)
+		}
+		discount := DiscountNone
+		if resp.Data.Free {
+			discount = DiscountFree
+		}
+		item := &TorrentItem{
+			ID:            strconv.FormatInt(resp.Data.ID, 10),
+			Title:         resp.Data.Name,
+			SizeBytes:     resp.Data.Size,
+			DiscountLevel: discount,
+			SourceSite:    d.siteID(),
+		}
+		// The API path has no detail page document, so only enrichers
+		// that don't need one can add anything here.
+		if err := ApplyEnrichment(ctx, d.siteDef, item, nil); err != nil {
+			d.logger.Warn("enrich torrent detail", zap.String("id", item.ID), zap.Error(err))
+		}
+		return item, nil
+	}
+
+	doc, err := d.fetchDocument(ctx, "/torrents/"+id)
+	if err != nil {
+		return nil, fmt.Errorf("fetch torrent detail via HTML for %s: %w", id, err)
+	}
+	parser := NewNexusPHPParserFromDefinition(d.siteDef)
+	title, torrentID := parser.ParseTitleAndID(doc.Selection)
+	sizeMB := parser.ParseSizeMB(doc.Selection)
+	item := &TorrentItem{
+		ID:         torrentID,
+		Title:      title,
+		Link:       d.hosts.ActiveBaseURL() + "/torrents/" + id,
+		SizeBytes:  int64(sizeMB * 1024 * 1024),
+		SourceSite: d.siteID(),
+	}
+
+	// Enrichment (IMDb/Douban ratings, dup-check) is optional metadata;
+	// a flaky dup-check endpoint shouldn't fail the whole detail fetch.
+	if err := ApplyEnrichment(ctx, d.siteDef, item, doc.Selection); err != nil {
+		d.logger.Warn("enrich torrent detail", zap.String("id", item.ID), zap.Error(err))
+	}
+
+	return item, nil
+}
+
+func (d *UNIT3DDriver) siteID() string {
+	if d.siteDef != nil {
+		return d.siteDef.ID
+	}
+	return ""
+}
+
+// extractUNIT3DTorrentID pulls the numeric torrent ID out of a UNIT3D
+// detail link such as https://acm.example/torrents/12345-some-title.
+func extractUNIT3DTorrentID(link string) string {
+	if link == "" {
+		return ""
+	}
+	trimmed := strings.TrimSuffix(link, "/")
+	idx := strings.LastIndex(trimmed, "/")
+	if idx == -1 {
+		return ""
+	}
+	segment := trimmed[idx+1:]
+	if dash := strings.Index(segment, "-"); dash != -1 {
+		segment = segment[:dash]
+	}
+	return segment
+}